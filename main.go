@@ -1,149 +1,107 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
 	"fmt"
 	"log"
-	"net/http"
+	"net/url"
 	"os"
-	"strconv"
+	"path/filepath"
 	"strings"
 
-	"github.com/PuerkitoBio/goquery"
+	"github.com/RyanFin/secret-message-decoder/pkg/decoder"
 )
 
-// Cell represents a character to be drawn at a specific (X, Y) coordinate.
-type Cell struct {
-	X int    // X-coordinate (column)
-	Y int    // Y-coordinate (row)
-	C string // Character to draw
-}
-
 func main() {
-	// Ensure a URL is provided as a command-line argument.
-	if len(os.Args) < 2 {
-		log.Fatal("Usage: go run main.go <public_google_doc_url>")
-	}
-	url := os.Args[1]
+	format := flag.String("format", "text", "output format: text, svg, png, or ansi")
+	cacheDir := flag.String("cache", "", "cache HTTP(S) fetches under this directory, keyed by URL hash, instead of re-fetching on every run")
+	flag.Parse()
 
-	// Fetch the content of the Google Doc.
-	resp, err := http.Get(url)
-	if err != nil {
-		log.Fatalf("Failed to fetch document: %v", err)
+	args := flag.Args()
+	if len(args) < 1 {
+		log.Fatal("Usage: go run main.go [-format=text|svg|png|ansi] [-cache=dir] <google_doc_url|file|->")
 	}
-	defer resp.Body.Close()
+	source := args[0]
 
-	// Check for successful HTTP response.
-	if resp.StatusCode != 200 {
-		log.Fatalf("Failed to fetch document: HTTP %d", resp.StatusCode)
+	renderer, err := decoder.RendererFor(*format)
+	if err != nil {
+		log.Fatal(err)
 	}
 
-	// Parse the HTML content of the document.
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	cells, err := loadCells(context.Background(), source, *cacheDir)
 	if err != nil {
-		log.Fatalf("Failed to parse document HTML: %v", err)
+		log.Fatalf("Failed to load document: %v", err)
 	}
 
-	// Debug print to verify the parsed document (can be removed later).
-	fmt.Println("document: ", doc)
-
-	// extract the grid structure from the Google Doc and store in a cell table structure
-	cells := parseCharacterGridFromDoc(doc)
-
-	fmt.Println("cells: ", cells)
-
 	// Exit if no valid data was parsed.
 	if len(cells) == 0 {
-		// exit the program
 		log.Fatal("No valid table data found.")
 	}
 
-	renderGridFromCells(cells)
-
+	if err := renderer.Render(cells, os.Stdout); err != nil {
+		log.Fatalf("Failed to render grid: %v", err)
+	}
 }
 
-// parseTableFromDoc parses the first HTML table in the provided goquery.Document,
-// extracting rows that contain three columns: x-coordinate, character, and y-coordinate.
-//
-// @param doc *goquery.Document - The parsed HTML document containing the table.
-// @returns []Cell - A slice of Cell structs representing characters positioned by their x and y coordinates.
-//
-// The function expects the table rows (except the header) to have exactly three columns:
-// - The first column is the x-coordinate (int).
-// - The second column is a character (string).
-// - The third column is the y-coordinate (int).
-//
-// Rows with invalid or missing data are skipped.
-// The returned slice can be used to reconstruct a character grid based on these coordinates.
-func parseCharacterGridFromDoc(doc *goquery.Document) []Cell {
-	var cells []Cell
-
-	// Find the first table in the document and iterate over its rows.
-	doc.Find("table").First().Find("tr").Each(func(i int, s *goquery.Selection) {
-		if i == 0 {
-			return // Skip the header row.
-		}
-
-		tds := s.Find("td")
-		if tds.Length() < 3 {
-			return // Skip if there aren't enough columns.
-		}
+// loadCells resolves source -- "-" for stdin, a "file://" URL, a bare
+// filesystem path, or an http(s) URL -- and parses the character grid from
+// it.
+func loadCells(ctx context.Context, source, cacheDir string) ([]decoder.Cell, error) {
+	if source == "-" {
+		return decoder.Parse(os.Stdin)
+	}
 
-		// Extract x-coordinate, character, and y-coordinate values.
-		xStr := strings.TrimSpace(tds.Eq(0).Text())
-		char := strings.TrimSpace(tds.Eq(1).Text())
-		yStr := strings.TrimSpace(tds.Eq(2).Text())
+	if path, ok := strings.CutPrefix(source, "file://"); ok {
+		return parseFile(path)
+	}
 
-		// Convert x and y to integers.
-		x, err1 := strconv.Atoi(xStr)
-		y, err2 := strconv.Atoi(yStr)
+	u, err := url.Parse(source)
+	if err != nil || u.Scheme == "" {
+		return parseFile(source)
+	}
 
-		// Skip rows with invalid integer conversion.
-		if err1 != nil || err2 != nil {
-			log.Printf("Skipping invalid row: %v %v\n", xStr, yStr)
-			return
-		}
+	if cacheDir != "" {
+		return fetchCached(ctx, source, cacheDir)
+	}
+	return decoder.Fetch(ctx, source)
+}
 
-		// Add the parsed cell to the list.
-		cells = append(cells, Cell{X: x, Y: y, C: char})
-	})
+func parseFile(path string) ([]decoder.Cell, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening %s: %w", path, err)
+	}
+	defer f.Close()
 
-	return cells
+	return decoder.Parse(f)
 }
 
-// renderGridFromCells takes a slice of Cell structs representing characters with X,Y coordinates,
-// builds a 2D grid of characters, and prints it to the console with the Y-axis flipped (bottom-to-top).
-//
-// @param cells []Cell - slice of Cell structs containing X, Y coordinates and character C to be placed.
-// @returns none (prints output directly to stdout).
-func renderGridFromCells(cells []Cell) {
-	// Determine max X and Y coordinates to define grid size
-	maxX, maxY := 0, 0
-	for _, c := range cells {
-		if c.X > maxX {
-			maxX = c.X
-		}
-		if c.Y > maxY {
-			maxY = c.Y
-		}
-	}
+// fetchCached fetches rawURL through cacheDir: the first fetch writes the
+// response body to a file keyed by a hash of the URL, and subsequent runs
+// read from that file instead of hitting the network again. This is meant
+// for iterating on the parser offline without rate-limiting yourself out.
+func fetchCached(ctx context.Context, rawURL, cacheDir string) ([]decoder.Cell, error) {
+	sum := sha256.Sum256([]byte(rawURL))
+	path := filepath.Join(cacheDir, hex.EncodeToString(sum[:])+".html")
 
-	// Initialize 2D grid filled with spaces
-	grid := make([][]string, maxY+1)
-	for i := range grid {
-		grid[i] = make([]string, maxX+1)
-		for j := range grid[i] {
-			grid[i][j] = " "
+	body, err := os.ReadFile(path)
+	if err != nil {
+		body, _, err = decoder.NewFetcher().FetchBody(ctx, rawURL)
+		if err != nil {
+			return nil, err
 		}
-	}
 
-	// Place each character at its (X,Y) position in the grid
-	for _, c := range cells {
-		grid[c.Y][c.X] = c.C
+		if err := os.MkdirAll(cacheDir, 0o755); err != nil {
+			return nil, fmt.Errorf("creating cache dir: %w", err)
+		}
+		if err := os.WriteFile(path, body, 0o644); err != nil {
+			return nil, fmt.Errorf("writing cache file: %w", err)
+		}
 	}
 
-	// Print the grid from bottom (maxY) to top (0), trimming trailing spaces per line
-	for i := maxY; i >= 0; i-- {
-		line := strings.Join(grid[i], "")
-		fmt.Println(strings.TrimRight(line, " "))
-	}
+	return decoder.Parse(bytes.NewReader(body))
 }
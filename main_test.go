@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadCellsFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "grid.txt")
+	if err := os.WriteFile(path, []byte("x\tchar\ty\n0\t#\t0\n"), 0o644); err != nil {
+		t.Fatalf("writing fixture: %v", err)
+	}
+
+	cells, err := loadCells(context.Background(), path, "")
+	if err != nil {
+		t.Fatalf("loadCells() error = %v", err)
+	}
+	if len(cells) != 1 || cells[0].C != "#" {
+		t.Errorf("cells = %+v, want one cell '#'", cells)
+	}
+}
+
+func TestLoadCellsFromFileURL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "grid.txt")
+	if err := os.WriteFile(path, []byte("x\tchar\ty\n0\t#\t0\n"), 0o644); err != nil {
+		t.Fatalf("writing fixture: %v", err)
+	}
+
+	cells, err := loadCells(context.Background(), "file://"+path, "")
+	if err != nil {
+		t.Fatalf("loadCells() error = %v", err)
+	}
+	if len(cells) != 1 || cells[0].C != "#" {
+		t.Errorf("cells = %+v, want one cell '#'", cells)
+	}
+}
+
+func TestFetchCachedWritesAndReusesCache(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("x\tchar\ty\n0\t#\t0\n"))
+	}))
+	defer srv.Close()
+
+	cacheDir := t.TempDir()
+
+	for i := 0; i < 2; i++ {
+		cells, err := fetchCached(context.Background(), srv.URL, cacheDir)
+		if err != nil {
+			t.Fatalf("fetchCached() error = %v", err)
+		}
+		if len(cells) != 1 || cells[0].C != "#" {
+			t.Errorf("cells = %+v, want one cell '#'", cells)
+		}
+	}
+
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1 (second call should hit the cache)", requests)
+	}
+}
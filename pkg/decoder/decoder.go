@@ -0,0 +1,248 @@
+// Package decoder extracts and renders the character grids hidden in the
+// "secret message" Google Docs puzzles: a table (or published-HTML
+// equivalent) of (x, character, y) rows that, once plotted onto a grid,
+// spells out a message.
+package decoder
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Cell represents a character to be drawn at a specific (X, Y) coordinate.
+type Cell struct {
+	X int    // X-coordinate (column)
+	Y int    // Y-coordinate (row)
+	C string // Character to draw
+}
+
+// Parser extracts a character grid from a fetched document body. Different
+// Google Docs export/publish formats need different extraction logic, so
+// each format gets its own Parser implementation.
+type Parser interface {
+	Parse(r io.Reader) ([]Cell, error)
+}
+
+// docIDPattern matches the document ID segment shared by every Google Docs
+// URL shape: /document/d/<id>/...
+var docIDPattern = regexp.MustCompile(`^/document/d/([a-zA-Z0-9_-]+)`)
+
+// NormalizeGoogleDocURL rewrites the various shapes a user might paste --
+// the `/edit` share link, the `/pub` "Publish to the web" link, its
+// `?embedded=true` variant, or an already-canonical `/export` link -- into
+// the form most likely to parse cleanly. Non-Google-Docs URLs, and shapes we
+// don't recognize, are returned unchanged.
+func NormalizeGoogleDocURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || !strings.HasSuffix(u.Host, "docs.google.com") {
+		return raw
+	}
+
+	m := docIDPattern.FindStringSubmatch(u.Path)
+	if m == nil {
+		return raw
+	}
+	id := m[1]
+
+	switch {
+	case strings.Contains(u.Path, "/export"):
+		// Already a direct export link; leave the requested format alone.
+		return raw
+	case strings.Contains(u.Path, "/pub"):
+		// Published HTML has its own parser; nothing to rewrite.
+		return raw
+	default:
+		// Bare share link or /edit: the HTML export renders a real <table>,
+		// which is the easiest shape to parse.
+		return fmt.Sprintf("https://docs.google.com/document/d/%s/export?format=html", id)
+	}
+}
+
+// selectParser chooses the Parser backend for a document body. contentType
+// may be empty (e.g. when parsing a local file), in which case the choice
+// falls back to sniffing the body itself.
+func selectParser(contentType string, body []byte) Parser {
+	switch {
+	case strings.Contains(contentType, "text/plain"):
+		return tsvParser{}
+	case !strings.Contains(contentType, "text/html") && looksLikeTSV(body):
+		return tsvParser{}
+	case bytes.Contains(body, []byte("<table")):
+		return tableParser{}
+	default:
+		return publishedHTMLParser{}
+	}
+}
+
+// looksLikeTSV reports whether body looks like a plain-text TSV export
+// rather than HTML: no opening angle bracket before the first tab.
+func looksLikeTSV(body []byte) bool {
+	tab := bytes.IndexByte(body, '\t')
+	if tab < 0 {
+		return false
+	}
+	lt := bytes.IndexByte(body, '<')
+	return lt < 0 || tab < lt
+}
+
+// Parse extracts the character grid from r, auto-detecting which of the
+// supported Google Docs formats (HTML table, published HTML, or plain-text
+// TSV) the content is in.
+func Parse(r io.Reader) ([]Cell, error) {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading document: %w", err)
+	}
+
+	parser := selectParser("", body)
+	return parser.Parse(bytes.NewReader(body))
+}
+
+// tableParser parses the HTML table emitted by the `/edit` and
+// `/export?format=html` doc shapes: one `<table>` whose rows (after a
+// header) each have three columns: x-coordinate, character, and
+// y-coordinate.
+type tableParser struct{}
+
+// Parse implements Parser by extracting rows that contain three columns:
+// x-coordinate, character, and y-coordinate.
+//
+// Rows with invalid or missing data are skipped. The returned slice can be
+// used to reconstruct a character grid based on these coordinates.
+func (tableParser) Parse(r io.Reader) ([]Cell, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("parsing table HTML: %w", err)
+	}
+
+	var cells []Cell
+
+	// Find the first table in the document and iterate over its rows.
+	doc.Find("table").First().Find("tr").Each(func(i int, s *goquery.Selection) {
+		if i == 0 {
+			return // Skip the header row.
+		}
+
+		tds := s.Find("td")
+		if tds.Length() < 3 {
+			return // Skip if there aren't enough columns.
+		}
+
+		// Extract x-coordinate, character, and y-coordinate values.
+		xStr := strings.TrimSpace(tds.Eq(0).Text())
+		char := strings.TrimSpace(tds.Eq(1).Text())
+		yStr := strings.TrimSpace(tds.Eq(2).Text())
+
+		// Convert x and y to integers.
+		x, err1 := strconv.Atoi(xStr)
+		y, err2 := strconv.Atoi(yStr)
+
+		// Skip rows with invalid integer conversion.
+		if err1 != nil || err2 != nil {
+			log.Printf("Skipping invalid row: %v %v\n", xStr, yStr)
+			return
+		}
+
+		// Add the parsed cell to the list.
+		cells = append(cells, Cell{X: x, Y: y, C: char})
+	})
+
+	return cells, nil
+}
+
+// publishedHTMLParser parses the markup Google serves for "Publish to the
+// web" links (`/pub` and `/pub?embedded=true`). That export flattens the
+// table into runs of styled `<span>` elements inside `<p>` tags rather than
+// a `<table>`, with one "x, char, y" triple per paragraph.
+type publishedHTMLParser struct{}
+
+// publishedRowPattern matches a "x, char, y" triple, tolerating the
+// whitespace and punctuation Google's publish renderer tends to insert
+// between spans.
+var publishedRowPattern = regexp.MustCompile(`(-?\d+)\s*,\s*(\S+)\s*,\s*(-?\d+)`)
+
+// Parse implements Parser by scanning each paragraph's flattened text for an
+// "x, char, y" triple.
+func (publishedHTMLParser) Parse(r io.Reader) ([]Cell, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("parsing published HTML: %w", err)
+	}
+
+	var cells []Cell
+
+	doc.Find("p").Each(func(i int, s *goquery.Selection) {
+		line := strings.TrimSpace(s.Text())
+		if line == "" {
+			return
+		}
+
+		m := publishedRowPattern.FindStringSubmatch(line)
+		if m == nil {
+			return // Not a data row (title, header, blank paragraph, ...).
+		}
+
+		x, err1 := strconv.Atoi(m[1])
+		y, err2 := strconv.Atoi(m[3])
+		if err1 != nil || err2 != nil {
+			log.Printf("Skipping invalid row: %v\n", line)
+			return
+		}
+
+		cells = append(cells, Cell{X: x, Y: y, C: m[2]})
+	})
+
+	return cells, nil
+}
+
+// tsvParser parses the plain-text tab-separated output Google serves for
+// `/export?format=txt`: one "x\tchar\ty" row per line, with a header row to
+// skip.
+type tsvParser struct{}
+
+// Parse implements Parser by splitting each line on tabs.
+func (tsvParser) Parse(r io.Reader) ([]Cell, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading TSV body: %w", err)
+	}
+
+	var cells []Cell
+
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		if i == 0 {
+			continue // Skip the header row.
+		}
+
+		fields := strings.Split(line, "\t")
+		if len(fields) < 3 {
+			continue // Skip if there aren't enough columns.
+		}
+
+		xStr := strings.TrimSpace(fields[0])
+		char := strings.TrimSpace(fields[1])
+		yStr := strings.TrimSpace(fields[2])
+
+		x, err1 := strconv.Atoi(xStr)
+		y, err2 := strconv.Atoi(yStr)
+		if err1 != nil || err2 != nil {
+			if xStr != "" || char != "" || yStr != "" {
+				log.Printf("Skipping invalid row: %v %v\n", xStr, yStr)
+			}
+			continue
+		}
+
+		cells = append(cells, Cell{X: x, Y: y, C: char})
+	}
+
+	return cells, nil
+}
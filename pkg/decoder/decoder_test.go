@@ -0,0 +1,110 @@
+package decoder
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseGoldenFixtures(t *testing.T) {
+	want := []Cell{
+		{X: 0, Y: 0, C: "#"},
+		{X: 1, Y: 0, C: "#"},
+		{X: 0, Y: 1, C: "#"},
+		{X: 1, Y: 1, C: "#"},
+	}
+
+	tests := []struct {
+		name string
+		file string
+	}{
+		{name: "html table export", file: "testdata/table.html"},
+		{name: "published to the web HTML", file: "testdata/published.html"},
+		{name: "plain-text TSV export", file: "testdata/plain.txt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(tt.file)
+			if err != nil {
+				t.Fatalf("opening fixture: %v", err)
+			}
+			defer f.Close()
+
+			got, err := Parse(f)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Parse() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	cells := []Cell{
+		{X: 0, Y: 0, C: "#"},
+		{X: 1, Y: 0, C: "#"},
+		{X: 0, Y: 1, C: "#"},
+		{X: 1, Y: 1, C: "#"},
+	}
+
+	var buf bytes.Buffer
+	if err := Render(cells, &buf); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	want := "##\n##\n"
+	if buf.String() != want {
+		t.Errorf("Render() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestRenderWithOptions(t *testing.T) {
+	cells := []Cell{
+		{X: 0, Y: 0, C: "A"},
+		{X: 2000, Y: 2000, C: "B"},
+	}
+
+	var buf bytes.Buffer
+	err := RenderWithOptions(cells, RenderOptions{
+		Writer:          &buf,
+		Origin:          OriginTopLeft,
+		TrailingNewline: false,
+	})
+	if err != nil {
+		t.Fatalf("RenderWithOptions() error = %v", err)
+	}
+
+	want := "A\n" + strings.Repeat(" ", 2000) + "B"
+	if buf.String() != want {
+		t.Errorf("RenderWithOptions() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestRenderWithOptionsNegativeX(t *testing.T) {
+	cells := []Cell{
+		{X: -1, Y: 0, C: "A"},
+		{X: 0, Y: 0, C: "B"},
+	}
+
+	var buf bytes.Buffer
+	if err := Render(cells, &buf); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	want := "AB\n"
+	if buf.String() != want {
+		t.Errorf("Render() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestRenderWithOptionsRequiresWriter(t *testing.T) {
+	if err := RenderWithOptions(nil, RenderOptions{}); err == nil {
+		t.Error("RenderWithOptions() with nil Writer = nil error, want non-nil")
+	}
+}
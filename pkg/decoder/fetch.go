@@ -0,0 +1,186 @@
+package decoder
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultUserAgent      = "secret-message-decoder/1.0 (+https://github.com/RyanFin/secret-message-decoder)"
+	defaultTimeout        = 15 * time.Second
+	defaultMaxRetries     = 3
+	defaultInitialBackoff = 250 * time.Millisecond
+	defaultMaxBackoff     = 4 * time.Second
+
+	// maxErrorBodySnippet bounds how much of a non-200 response body we
+	// quote in an error, so a Google error page doesn't flood the log.
+	maxErrorBodySnippet = 512
+)
+
+// Fetcher retrieves a Google Doc over HTTP, retrying transient failures with
+// exponential backoff and jitter.
+type Fetcher struct {
+	// Client performs the actual requests. If nil, an *http.Client with
+	// Timeout set to defaultTimeout is used -- the zero value Fetcher{}
+	// is valid, not just the result of NewFetcher().
+	Client *http.Client
+	// UserAgent is sent on every request. Google occasionally rate-limits
+	// or serves a cookie-consent interstitial to requests that look like
+	// they're coming from Go's default User-Agent.
+	UserAgent string
+	// MaxRetries is how many times to retry a failed request (so up to
+	// MaxRetries+1 attempts total) on 5xx responses and transient network
+	// errors.
+	MaxRetries int
+	// InitialBackoff is the sleep before the first retry. Each
+	// subsequent retry doubles it, up to MaxBackoff.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the doubling backoff.
+	MaxBackoff time.Duration
+}
+
+// NewFetcher returns a Fetcher configured with sensible defaults.
+func NewFetcher() *Fetcher {
+	return &Fetcher{
+		Client:         &http.Client{Timeout: defaultTimeout},
+		UserAgent:      defaultUserAgent,
+		MaxRetries:     defaultMaxRetries,
+		InitialBackoff: defaultInitialBackoff,
+		MaxBackoff:     defaultMaxBackoff,
+	}
+}
+
+// Fetch retrieves the Google Doc at url, normalizing its shape first, and
+// parses the resulting body with the Parser backend appropriate for the
+// Content-Type Google served. It retries 5xx responses and transient network
+// errors with exponential backoff, and honors ctx cancellation both between
+// attempts and while sleeping.
+func (f *Fetcher) Fetch(ctx context.Context, url string) ([]Cell, error) {
+	body, contentType, err := f.FetchBody(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := selectParser(contentType, body)
+
+	cells, err := parser.Parse(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("parsing document: %w", err)
+	}
+
+	return cells, nil
+}
+
+// FetchBody retrieves the raw response body for the Google Doc at url,
+// normalizing its shape first, without parsing it. This is the retrying
+// primitive Fetch is built on; callers that want to cache the raw HTML (so
+// repeated runs don't re-hit Google) can use it directly.
+func (f *Fetcher) FetchBody(ctx context.Context, url string) (body []byte, contentType string, err error) {
+	fetchURL := NormalizeGoogleDocURL(url)
+
+	var lastErr error
+	backoff := f.InitialBackoff
+
+	for attempt := 0; attempt <= f.MaxRetries; attempt++ {
+		if attempt > 0 {
+			if err := sleepWithContext(ctx, jitter(backoff)); err != nil {
+				return nil, "", err
+			}
+			backoff *= 2
+			if backoff > f.MaxBackoff {
+				backoff = f.MaxBackoff
+			}
+		}
+
+		body, contentType, retryable, err := f.attempt(ctx, fetchURL)
+		if err == nil {
+			return body, contentType, nil
+		}
+		lastErr = err
+		if !retryable {
+			return nil, "", err
+		}
+	}
+
+	return nil, "", fmt.Errorf("fetching document: giving up after %d attempts: %w", f.MaxRetries+1, lastErr)
+}
+
+// client returns f.Client, falling back to a default-timeout client when
+// f.Client is nil.
+func (f *Fetcher) client() *http.Client {
+	if f.Client != nil {
+		return f.Client
+	}
+	return &http.Client{Timeout: defaultTimeout}
+}
+
+// attempt performs a single fetch. The bool return reports whether the
+// error (if any) is worth retrying.
+func (f *Fetcher) attempt(ctx context.Context, fetchURL string) (body []byte, contentType string, retryable bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fetchURL, nil)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("building request: %w", err)
+	}
+	req.Header.Set("User-Agent", f.UserAgent)
+
+	resp, err := f.client().Do(req)
+	if err != nil {
+		return nil, "", true, fmt.Errorf("fetching document: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", true, fmt.Errorf("reading document body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		err := fmt.Errorf("fetching document: HTTP %d: %s", resp.StatusCode, snippet(body))
+		return nil, "", resp.StatusCode >= 500, err
+	}
+
+	return body, resp.Header.Get("Content-Type"), false, nil
+}
+
+// snippet trims body down to a bounded, single-line preview suitable for an
+// error message -- e.g. so users can see when Google returned an HTML error
+// page instead of the doc.
+func snippet(body []byte) string {
+	s := string(bytes.Join(bytes.Fields(body), []byte(" ")))
+	if len(s) > maxErrorBodySnippet {
+		s = s[:maxErrorBodySnippet] + "..."
+	}
+	return s
+}
+
+// jitter returns d plus or minus up to 10%, so concurrent retries don't all
+// wake up in lockstep.
+func jitter(d time.Duration) time.Duration {
+	delta := time.Duration(rand.Int63n(int64(d)/5+1)) - time.Duration(int64(d)/10)
+	return d + delta
+}
+
+// sleepWithContext sleeps for d, or returns ctx.Err() if ctx is canceled
+// first.
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Fetch retrieves the Google Doc at url using a Fetcher configured with
+// default settings. It's a convenience wrapper for callers who don't need
+// to customize retry or timeout behavior.
+func Fetch(ctx context.Context, url string) ([]Cell, error) {
+	return NewFetcher().Fetch(ctx, url)
+}
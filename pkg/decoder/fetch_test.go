@@ -0,0 +1,118 @@
+package decoder
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestZeroValueFetcherIsValid(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("x\tchar\ty\n0\t#\t0\n"))
+	}))
+	defer srv.Close()
+
+	f := &Fetcher{MaxRetries: 1}
+
+	cells, err := f.Fetch(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if len(cells) != 1 || cells[0].C != "#" {
+		t.Errorf("cells = %+v, want one cell '#'", cells)
+	}
+}
+
+func TestFetcherRetriesOn5xxThenSucceeds(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("x\tchar\ty\n0\t#\t0\n"))
+	}))
+	defer srv.Close()
+
+	f := NewFetcher()
+	f.InitialBackoff = time.Millisecond
+	f.MaxBackoff = 5 * time.Millisecond
+
+	cells, err := f.Fetch(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if requests != 3 {
+		t.Errorf("requests = %d, want 3", requests)
+	}
+	if len(cells) != 1 || cells[0].C != "#" {
+		t.Errorf("cells = %+v, want one cell '#'", cells)
+	}
+}
+
+func TestFetcherSendsUserAgent(t *testing.T) {
+	var gotUA string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUA = r.Header.Get("User-Agent")
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("x\tchar\ty\n"))
+	}))
+	defer srv.Close()
+
+	f := NewFetcher()
+	if _, err := f.Fetch(context.Background(), srv.URL); err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if gotUA != defaultUserAgent {
+		t.Errorf("User-Agent = %q, want %q", gotUA, defaultUserAgent)
+	}
+}
+
+func TestFetcherDoesNotRetry4xx(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("not found"))
+	}))
+	defer srv.Close()
+
+	f := NewFetcher()
+	f.InitialBackoff = time.Millisecond
+
+	_, err := f.Fetch(context.Background(), srv.URL)
+	if err == nil {
+		t.Fatal("Fetch() error = nil, want non-nil")
+	}
+	if !strings.Contains(err.Error(), "not found") {
+		t.Errorf("error = %v, want it to include the response body snippet", err)
+	}
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1 (no retry on 4xx)", requests)
+	}
+}
+
+func TestFetcherHonorsContextCancellation(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	f := NewFetcher()
+	f.InitialBackoff = 50 * time.Millisecond
+	f.MaxRetries = 5
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := f.Fetch(ctx, srv.URL)
+	if err == nil {
+		t.Fatal("Fetch() error = nil, want non-nil")
+	}
+}
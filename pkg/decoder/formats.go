@@ -0,0 +1,199 @@
+package decoder
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"strings"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// Renderer draws a decoded character grid in some output format.
+type Renderer interface {
+	Render(cells []Cell, w io.Writer) error
+}
+
+// RendererFor resolves the -format flag value to a Renderer. An empty
+// string is treated as "text".
+func RendererFor(format string) (Renderer, error) {
+	switch format {
+	case "", "text":
+		return TextRenderer{}, nil
+	case "svg":
+		return SVGRenderer{}, nil
+	case "png":
+		return PNGRenderer{}, nil
+	case "ansi":
+		return ANSIRenderer{}, nil
+	default:
+		return nil, fmt.Errorf("unknown output format %q", format)
+	}
+}
+
+// TextRenderer renders the plain ASCII grid produced by Render.
+type TextRenderer struct{}
+
+// Render implements Renderer.
+func (TextRenderer) Render(cells []Cell, w io.Writer) error {
+	return Render(cells, w)
+}
+
+// ANSIRenderer renders the same grid as TextRenderer, but colorizes
+// non-space cells for terminals that support ANSI escapes.
+type ANSIRenderer struct {
+	// Color is the SGR color code to wrap non-space cells in. Defaults to
+	// 32 (green) when zero.
+	Color int
+}
+
+const ansiReset = "\x1b[0m"
+
+// Render implements Renderer.
+func (r ANSIRenderer) Render(cells []Cell, w io.Writer) error {
+	color := r.Color
+	if color == 0 {
+		color = 32
+	}
+	prefix := fmt.Sprintf("\x1b[%dm", color)
+
+	var plain strings.Builder
+	if err := Render(cells, &plain); err != nil {
+		return err
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(plain.String(), "\n"), "\n") {
+		var out strings.Builder
+		for _, r := range line {
+			if r == ' ' {
+				out.WriteRune(r)
+				continue
+			}
+			out.WriteString(prefix)
+			out.WriteRune(r)
+			out.WriteString(ansiReset)
+		}
+		if _, err := fmt.Fprintln(w, out.String()); err != nil {
+			return fmt.Errorf("writing ANSI grid: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// SVGRenderer renders the grid as one <text> element per cell, suitable for
+// embedding in a README or slide.
+type SVGRenderer struct {
+	// CellSize is the width/height, in pixels, reserved per grid cell.
+	// Defaults to 16 when zero.
+	CellSize int
+	// ShowBounds draws a bounding <rect> around the whole grid.
+	ShowBounds bool
+}
+
+// Render implements Renderer.
+func (r SVGRenderer) Render(cells []Cell, w io.Writer) error {
+	cellSize := r.CellSize
+	if cellSize == 0 {
+		cellSize = 16
+	}
+
+	minX, maxX, maxY := bounds(cells)
+	width := (maxX - minX + 1) * cellSize
+	height := (maxY + 1) * cellSize
+
+	fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="monospace" font-size="%d">`+"\n",
+		width, height, cellSize)
+
+	if r.ShowBounds {
+		fmt.Fprintf(w, `<rect x="0" y="0" width="%d" height="%d" fill="none" stroke="black"/>`+"\n", width, height)
+	}
+
+	for _, c := range cells {
+		// Flip Y so the SVG (top-down) matches the text renderer's
+		// bottom-up convention.
+		py := (maxY - c.Y) * cellSize
+		px := (c.X - minX) * cellSize
+		fmt.Fprintf(w, `<text x="%d" y="%d">%s</text>`+"\n", px, py+cellSize, svgEscape(c.C))
+	}
+
+	fmt.Fprintln(w, `</svg>`)
+
+	return nil
+}
+
+// bounds returns the minimum X, maximum X, and maximum Y across cells,
+// mirroring the range RenderWithOptions covers so the SVG/PNG renderers
+// don't clip cells with a negative X.
+func bounds(cells []Cell) (minX, maxX, maxY int) {
+	for _, c := range cells {
+		if c.X < minX {
+			minX = c.X
+		}
+		if c.X > maxX {
+			maxX = c.X
+		}
+		if c.Y > maxY {
+			maxY = c.Y
+		}
+	}
+	return minX, maxX, maxY
+}
+
+func svgEscape(s string) string {
+	replacer := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	return replacer.Replace(s)
+}
+
+// PNGRenderer rasterizes the grid using a basic bitmap font, one glyph per
+// cell.
+type PNGRenderer struct {
+	// CellSize is the width/height, in pixels, reserved per grid cell.
+	// Defaults to 16 when zero.
+	CellSize int
+}
+
+// Render implements Renderer.
+func (r PNGRenderer) Render(cells []Cell, w io.Writer) error {
+	cellSize := r.CellSize
+	if cellSize == 0 {
+		cellSize = 16
+	}
+
+	minX, maxX, maxY := bounds(cells)
+	width := (maxX - minX + 1) * cellSize
+	height := (maxY + 1) * cellSize
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+
+	drawer := &font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(color.Black),
+		Face: basicfont.Face7x13,
+	}
+
+	for _, c := range cells {
+		// Flip Y so the PNG (top-down) matches the text renderer's
+		// bottom-up convention.
+		py := (maxY - c.Y) * cellSize
+		px := (c.X - minX) * cellSize
+		drawer.Dot = fixedPoint(px, py+cellSize-4)
+		drawer.DrawString(c.C)
+	}
+
+	if err := png.Encode(w, img); err != nil {
+		return fmt.Errorf("encoding PNG: %w", err)
+	}
+
+	return nil
+}
+
+func fixedPoint(x, y int) fixed.Point26_6 {
+	return fixed.Point26_6{X: fixed.I(x), Y: fixed.I(y)}
+}
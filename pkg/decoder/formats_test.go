@@ -0,0 +1,130 @@
+package decoder
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+var testCells = []Cell{
+	{X: 0, Y: 0, C: "#"},
+	{X: 1, Y: 0, C: "#"},
+}
+
+func TestRendererFor(t *testing.T) {
+	tests := []struct {
+		format  string
+		want    Renderer
+		wantErr bool
+	}{
+		{format: "", want: TextRenderer{}},
+		{format: "text", want: TextRenderer{}},
+		{format: "svg", want: SVGRenderer{}},
+		{format: "png", want: PNGRenderer{}},
+		{format: "ansi", want: ANSIRenderer{}},
+		{format: "bogus", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			got, err := RendererFor(tt.format)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("RendererFor(%q) error = nil, want non-nil", tt.format)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RendererFor(%q) error = %v", tt.format, err)
+			}
+			if got != tt.want {
+				t.Errorf("RendererFor(%q) = %#v, want %#v", tt.format, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSVGRendererEmitsOneTextElementPerCell(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (SVGRenderer{}).Render(testCells, &buf); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	out := buf.String()
+	if !strings.HasPrefix(out, "<svg") {
+		t.Errorf("output does not start with <svg: %q", out)
+	}
+	if strings.Count(out, "<text") != len(testCells) {
+		t.Errorf("got %d <text> elements, want %d", strings.Count(out, "<text"), len(testCells))
+	}
+}
+
+func TestPNGRendererProducesDecodablePNG(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (PNGRenderer{}).Render(testCells, &buf); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("png.Decode() error = %v", err)
+	}
+	if img.Bounds().Dx() == 0 || img.Bounds().Dy() == 0 {
+		t.Errorf("decoded image has empty bounds: %v", img.Bounds())
+	}
+}
+
+func TestSVGRendererNegativeX(t *testing.T) {
+	cells := []Cell{
+		{X: -1, Y: 0, C: "A"},
+		{X: 0, Y: 0, C: "B"},
+	}
+
+	var buf bytes.Buffer
+	if err := (SVGRenderer{}).Render(cells, &buf); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, `width="32"`) {
+		t.Errorf("canvas not widened to cover negative X: %q", out)
+	}
+	if !strings.Contains(out, `<text x="0" y="16">A</text>`) {
+		t.Errorf("cell at X=-1 not shifted onto the canvas: %q", out)
+	}
+	if !strings.Contains(out, `<text x="16" y="16">B</text>`) {
+		t.Errorf("cell at X=0 not shifted alongside it: %q", out)
+	}
+}
+
+func TestPNGRendererNegativeX(t *testing.T) {
+	cells := []Cell{
+		{X: -1, Y: 0, C: "A"},
+		{X: 0, Y: 0, C: "B"},
+	}
+
+	var buf bytes.Buffer
+	if err := (PNGRenderer{}).Render(cells, &buf); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("png.Decode() error = %v", err)
+	}
+	if got := img.Bounds().Dx(); got != 32 {
+		t.Errorf("canvas width = %d, want 32 (widened to cover negative X)", got)
+	}
+}
+
+func TestANSIRendererColorizesNonSpaceCells(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (ANSIRenderer{}).Render(testCells, &buf); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "\x1b[32m#"+ansiReset) {
+		t.Errorf("output missing colorized cell: %q", buf.String())
+	}
+}
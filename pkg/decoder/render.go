@@ -0,0 +1,114 @@
+package decoder
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Origin selects which corner of the grid line 0 of the rendered output
+// corresponds to.
+type Origin int
+
+const (
+	// OriginBottomLeft renders row maxY first, then counts down to the
+	// lowest Y present -- matching the puzzle convention where Y grows
+	// upward. This is the default used by Render.
+	OriginBottomLeft Origin = iota
+	// OriginTopLeft renders the lowest Y present first, counting up to
+	// maxY.
+	OriginTopLeft
+)
+
+// RenderOptions configures RenderWithOptions.
+type RenderOptions struct {
+	// Writer receives the rendered grid. Required.
+	Writer io.Writer
+	// Origin selects top-left or bottom-left row ordering. Defaults to
+	// OriginBottomLeft (the zero value).
+	Origin Origin
+	// TrailingNewline, if true, emits a final "\n" after the last row.
+	TrailingNewline bool
+}
+
+// Render takes a slice of Cell structs representing characters with X,Y
+// coordinates and writes them to w as a grid, with the Y-axis flipped
+// (bottom-to-top) and a trailing newline after the last row. It is a
+// convenience wrapper around RenderWithOptions for the common case.
+func Render(cells []Cell, w io.Writer) error {
+	return RenderWithOptions(cells, RenderOptions{
+		Writer:          w,
+		Origin:          OriginBottomLeft,
+		TrailingNewline: true,
+	})
+}
+
+// RenderWithOptions builds a sparse character grid from cells and writes it
+// to opts.Writer. Rows are keyed by Y so a single far-flung cell (e.g. at
+// Y=2000) costs one row, not two thousand empty ones; each row is built with
+// a single bytes.Buffer, padded with spaces from the lowest X actually used
+// (which may be below zero -- negative X coordinates, e.g. from
+// publishedHTMLParser, are rendered, not dropped), and has its trailing
+// spaces trimmed.
+func RenderWithOptions(cells []Cell, opts RenderOptions) error {
+	if opts.Writer == nil {
+		return fmt.Errorf("decoder: RenderOptions.Writer must not be nil")
+	}
+
+	rows := make(map[int]map[int]string)
+	minX, maxX := 0, 0
+	for _, c := range cells {
+		if c.X < minX {
+			minX = c.X
+		}
+		if c.X > maxX {
+			maxX = c.X
+		}
+		row := rows[c.Y]
+		if row == nil {
+			row = make(map[int]string)
+			rows[c.Y] = row
+		}
+		row[c.X] = c.C
+	}
+
+	ys := make([]int, 0, len(rows))
+	for y := range rows {
+		ys = append(ys, y)
+	}
+	sort.Ints(ys)
+	if opts.Origin == OriginBottomLeft {
+		for i, j := 0, len(ys)-1; i < j; i, j = i+1, j-1 {
+			ys[i], ys[j] = ys[j], ys[i]
+		}
+	}
+
+	lines := make([]string, len(ys))
+	for i, y := range ys {
+		row := rows[y]
+
+		var line bytes.Buffer
+		for x := minX; x <= maxX; x++ {
+			if c, ok := row[x]; ok {
+				line.WriteString(c)
+			} else {
+				line.WriteByte(' ')
+			}
+		}
+
+		lines[i] = strings.TrimRight(line.String(), " ")
+	}
+
+	output := strings.Join(lines, "\n")
+	if opts.TrailingNewline && len(lines) > 0 {
+		output += "\n"
+	}
+
+	if _, err := io.WriteString(opts.Writer, output); err != nil {
+		return fmt.Errorf("writing rendered grid: %w", err)
+	}
+
+	return nil
+}